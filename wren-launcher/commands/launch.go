@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -9,6 +8,8 @@ import (
 	"time"
 
 	"github.com/Canner/WrenAI/wren-launcher/config"
+	"github.com/Canner/WrenAI/wren-launcher/healthcheck"
+	"github.com/Canner/WrenAI/wren-launcher/providers"
 	utils "github.com/Canner/WrenAI/wren-launcher/utils"
 	"github.com/common-nighthawk/go-figure"
 	"github.com/manifoldco/promptui"
@@ -43,90 +44,208 @@ func evaluateTelemetryPreferences() (bool, error) {
 	return true, nil
 }
 
-func askForLLMProvider() (string, error) {
-	// let users know we're asking for a LLM provider
-	fmt.Println("Please provide the LLM provider you want to use")
-	fmt.Println("You can learn more about how to set up custom LLMs at https://docs.getwren.ai/installation/custom_llm#running-wren-ai-with-your-custom-llm-or-document-store")
+// launchParams holds everything needed to bring Wren AI up once the LLM
+// provider, credentials, and telemetry preference are known, regardless of
+// whether they came from interactive prompts or a config file.
+type launchParams struct {
+	llmProvider           string
+	openaiApiKey          string
+	openaiGenerationModel string
+	telemetryEnabled      bool
+	uiPort                int
+	aiPort                int
+	qdrantPort            int
+	wrenEnginePort        int
+	ibisPort              int
+	// provider, if set, renders its own .env.ai fragment after the
+	// docker-compose files are prepared. Both launch flows set it; for OpenAI
+	// its RenderEnv is a no-op, since the key/model are embedded directly.
+	provider providers.Provider
+	// healthOpts controls how long and how often each readiness probe
+	// retries before the launch is considered failed.
+	healthOpts healthcheck.Options
+	// healthzPort exposes the probe results over HTTP on this port while
+	// runLaunchSequence waits for the stack to come up. Zero disables it.
+	healthzPort int
+	// gpu is the requested --gpu preference ("auto", "none", "cuda", "rocm").
+	gpu string
+	// extraEnv, if set, runs after the provider has written its .env.ai
+	// fragment to append anything a provider-agnostic source (a config
+	// file's embedding model or model gallery) needs added. Nil for the
+	// interactive Launch flow, which has neither.
+	extraEnv func(projectDir string) error
+}
 
-	prompt := promptui.Select{
-		Label: "Select an LLM provider",
-		Items: []string{"OpenAI", "Custom"},
+// credentialsToVerify extracts the key/model pair to preflight for providers
+// utils.VerifyLLMCredentials knows how to check. It returns an empty key for
+// providers with nothing cheap to verify ahead of time.
+func credentialsToVerify(provider providers.Provider) (key string, model string) {
+	switch p := provider.(type) {
+	case *providers.OpenAIProvider:
+		return p.APIKey, p.Model
+	case *providers.AnthropicProvider:
+		return p.APIKey, p.Model
+	default:
+		return "", ""
 	}
+}
+
+func printHeader() {
+	fmt.Println(strings.Repeat("=", 55))
+	myFigure := figure.NewFigure("WrenAI", "", true)
+	myFigure.Print()
+	fmt.Println(strings.Repeat("=", 55))
+}
 
-	_, result, err := prompt.Run()
+// defaultPort returns port, falling back to def when port is unset.
+func defaultPort(port int, def int) int {
+	if port == 0 {
+		return def
+	}
+	return port
+}
 
-	if err != nil {
-		fmt.Printf("Prompt failed %v\n", err)
-		return "", err
+// buildServiceRegistry wires up probes for every dependent service in
+// startup order: Qdrant, Wren Engine, and Ibis must be healthy before the AI
+// service is probed, which in turn must be healthy before the UI is probed.
+func buildServiceRegistry(params launchParams, uiURL string) *healthcheck.Registry {
+	opts := params.healthOpts
+	if opts.Interval == 0 && opts.Timeout == 0 {
+		opts = healthcheck.DefaultOptions()
 	}
 
-	return result, nil
+	qdrantPort := defaultPort(params.qdrantPort, 6333)
+	wrenEnginePort := defaultPort(params.wrenEnginePort, 8080)
+	ibisPort := defaultPort(params.ibisPort, 8000)
+
+	registry := healthcheck.NewRegistry()
+	registry.Add(healthcheck.NewProbe("qdrant", nil, func() error {
+		return utils.CheckQdrantStarted(qdrantPort)
+	}, opts))
+	registry.Add(healthcheck.NewProbe("wren-engine", nil, func() error {
+		return utils.CheckWrenEngineStarted(wrenEnginePort)
+	}, opts))
+	registry.Add(healthcheck.NewProbe("ibis", nil, func() error {
+		return utils.CheckIbisServerStarted(ibisPort)
+	}, opts))
+	registry.Add(healthcheck.NewProbe("ai", []string{"qdrant", "wren-engine", "ibis"}, func() error {
+		return utils.CheckAIServiceStarted(params.aiPort)
+	}, opts))
+	registry.Add(healthcheck.NewProbe("ui", []string{"ai"}, func() error {
+		return utils.CheckUIServiceStarted(uiURL)
+	}, opts))
+
+	return registry
 }
 
-func askForAPIKey() (string, error) {
-	// let users know we're asking for an API key
-	fmt.Println("Please provide your OpenAI API key")
-	fmt.Println("Please use the key with full permission, more details at https://help.openai.com/en/articles/8867743-assign-api-key-permissions")
+// runLaunchSequence checks the Docker daemon, writes the compose/env files,
+// brings the stack up, waits for it to become healthy, and opens the browser.
+// It is shared by the interactive Launch flow and LaunchFromConfig.
+func runLaunchSequence(params launchParams, projectDir string) error {
+	// pick a Docker Compose profile matching the host's accelerators
+	accelerators := utils.DetectAccelerators()
+	profile, err := utils.SelectComposeProfile(params.gpu, accelerators)
+	if err != nil {
+		return err
+	}
+	pterm.Info.Printf("Selected Docker Compose profile: %s\n", profile)
+	if err := utils.ApplyComposeProfile(profile); err != nil {
+		return err
+	}
 
-	validate := func(input string) error {
-		// check if input is a valid API key
-		// OpenAI API keys are starting with "sk-"
-		if !strings.HasPrefix(input, "sk-") {
-			return errors.New("invalid API key")
+	// check if docker daemon is running, if not, open it and loop to check again
+	pterm.Info.Println("Checking if Docker daemon is running")
+	for {
+		_, err := utils.CheckDockerDaemonRunning()
+		if err == nil {
+			break
 		}
-		return nil
-	}
 
-	prompt := promptui.Prompt{
-		Label:    "OpenAI API key",
-		Validate: validate,
-		Mask:     '*',
-	}
+		pterm.Info.Println("Docker daemon is not running, opening Docker Desktop")
+		if err := utils.OpenDockerDaemon(); err != nil {
+			return err
+		}
 
-	result, err := prompt.Run()
+		time.Sleep(5 * time.Second)
+	}
 
+	// download docker-compose file and env file template for Wren AI
+	pterm.Info.Println("Downloading docker-compose file and env file")
+	err = utils.PrepareDockerFiles(params.openaiApiKey, params.openaiGenerationModel, params.uiPort, params.aiPort, projectDir, params.telemetryEnabled)
 	if err != nil {
-		fmt.Printf("Prompt failed %v\n", err)
-		return "", err
+		return err
 	}
 
-	return result, nil
-}
-
-func askForGenerationModel() (string, error) {
-	// let users know we're asking for a generation model
-	fmt.Println("Please provide the generation model you want to use")
-	fmt.Println("You can learn more about OpenAI's generation models at https://platform.openai.com/docs/models/models")
-
-	prompt := promptui.Select{
-		Label: "Select an OpenAI's generation model",
-		Items: []string{"gpt-4o", "gpt-4-turbo", "gpt-3.5-turbo"},
+	// let the chosen provider append its own .env.ai fragment, if any.
+	// RenderEnv appends rather than overwrites, so this is safe to call for
+	// every provider, including OpenAI (whose RenderEnv is a no-op since
+	// PrepareDockerFiles just wrote its fragment directly above).
+	if params.provider != nil {
+		if err := params.provider.RenderEnv(projectDir); err != nil {
+			return err
+		}
 	}
 
-	_, result, err := prompt.Run()
+	if params.extraEnv != nil {
+		if err := params.extraEnv(projectDir); err != nil {
+			return err
+		}
+	}
 
+	// launch Wren AI
+	pterm.Info.Println("Launching Wren AI")
+	const projectName string = "wrenai"
+	err = utils.RunDockerCompose(projectName, projectDir, params.llmProvider)
 	if err != nil {
-		fmt.Printf("Prompt failed %v\n", err)
-		return "", err
+		return err
 	}
 
-	return result, nil
-}
-
-func isEnvFileValidForCustomLLM(projectDir string) error {
-	// validate if .env.ai file exists in ~/.wrenai
-	envFilePath := path.Join(projectDir, ".env.ai")
+	// wait for every dependent service to report healthy
+	pterm.Info.Println("Wren AI is starting, please wait for a moment...")
+	url := fmt.Sprintf("http://localhost:%d", params.uiPort)
+
+	registry := buildServiceRegistry(params, url)
+
+	if params.healthzPort != 0 {
+		go func() {
+			addr := fmt.Sprintf(":%d", params.healthzPort)
+			pterm.Info.Printf("Exposing health status on http://localhost:%d/healthz\n", params.healthzPort)
+			if err := registry.Serve(addr); err != nil {
+				pterm.Warning.Println("Healthz server stopped:", err)
+			}
+		}()
+	}
 
-	if _, err := os.Stat(envFilePath); os.IsNotExist(err) {
-		errMessage := fmt.Sprintf("Please create a .env.ai file in %s first, more details at https://docs.getwren.ai/installation/custom_llm#running-wren-ai-with-your-custom-llm-or-document-store", projectDir)
-		return errors.New(errMessage)
+	for _, result := range registry.RunAll() {
+		if result.Status != healthcheck.StatusHealthy {
+			return fmt.Errorf("timeout waiting for %s service: %s", result.Name, result.Error)
+		}
+		pterm.Info.Printf("%s service is ready\n", strings.ToUpper(result.Name))
 	}
 
+	// open browser
+	pterm.Info.Println("Opening browser")
+	utils.Openbrowser(url)
+
+	pterm.Info.Println("You can now safely close this terminal window")
 	return nil
 }
 
+// LaunchOptions configures behavior that isn't gathered through the
+// interactive prompts.
+type LaunchOptions struct {
+	// GPU is the --gpu preference: "auto" (default), "none", "cuda", or "rocm".
+	GPU string
+	// Health controls the overall/per-probe timeout and retry counts used
+	// while waiting for the stack to come up.
+	Health healthcheck.Options
+	// HealthzPort, if non-zero, exposes probe results on
+	// http://localhost:<port>/healthz while the launcher waits for the stack
+	// to become healthy.
+	HealthzPort int
+}
 
-func Launch() {
+func Launch(opts LaunchOptions) {
 	// recover from panic
 	defer func() {
 		if r := recover(); r != nil {
@@ -136,10 +255,7 @@ func Launch() {
 	}()
 
 	// print Wren AI header
-	fmt.Println(strings.Repeat("=", 55))
-	myFigure := figure.NewFigure("WrenAI", "", true)
-	myFigure.Print()
-	fmt.Println(strings.Repeat("=", 55))
+	printHeader()
 
 	// prepare a project directory
 	pterm.Info.Println("Preparing project directory")
@@ -147,108 +263,75 @@ func Launch() {
 
 	// ask for LLM provider
 	pterm.Print("\n")
-	llmProvider, err := askForLLMProvider()
-	openaiApiKey := ""
-	openaiGenerationModel := ""
-	if llmProvider == "OpenAI" {
-		// ask for OpenAI API key
-		pterm.Print("\n")
-		openaiApiKey, _ = askForAPIKey()
-
-		// ask for OpenAI generation model
-		pterm.Print("\n")
-		openaiGenerationModel, _ = askForGenerationModel()
-	} else {
-		// check if .env.ai file exists
-		err = isEnvFileValidForCustomLLM(projectDir)
-		if err != nil {
-			panic(err)
-		}
-	}
+	fmt.Println("Please provide the LLM provider you want to use")
+	fmt.Println("You can learn more about how to set up custom LLMs at https://docs.getwren.ai/installation/custom_llm#running-wren-ai-with-your-custom-llm-or-document-store")
 
-	// ask for telemetry consent
-	pterm.Print("\n")
-	telemetryEnabled, err := evaluateTelemetryPreferences()
+	providerPrompt := promptui.Select{
+		Label: "Select an LLM provider",
+		Items: providers.Names(),
+	}
 
+	_, providerName, err := providerPrompt.Run()
 	if err != nil {
-		pterm.Error.Println("Failed to get API key")
 		panic(err)
 	}
 
-	// check if docker daemon is running, if not, open it and loop to check again
-	pterm.Info.Println("Checking if Docker daemon is running")
-	for {
-		_, err = utils.CheckDockerDaemonRunning()
-		if err == nil {
-			break
-		}
-
-		pterm.Info.Println("Docker daemon is not running, opening Docker Desktop")
-		err = utils.OpenDockerDaemon()
-		if err != nil {
-			panic(err)
-		}
-
-		time.Sleep(5 * time.Second)
-	}
-
-	// download docker-compose file and env file template for Wren AI
-	pterm.Info.Println("Downloading docker-compose file and env file")
-	// find an available port
-	uiPort := utils.FindAvailablePort(3000)
-	aiPort := utils.FindAvailablePort(5555)
-
-	err = utils.PrepareDockerFiles(openaiApiKey, openaiGenerationModel, uiPort, aiPort, projectDir, telemetryEnabled)
+	provider, err := providers.Find(providerName)
 	if err != nil {
 		panic(err)
 	}
 
-	// launch Wren AI
-	pterm.Info.Println("Launching Wren AI")
-	const projectName string = "wrenai"
-	err = utils.RunDockerCompose(projectName, projectDir, llmProvider)
-	if err != nil {
+	pterm.Print("\n")
+	if err := provider.Prompt(); err != nil {
+		panic(err)
+	}
+	if err := provider.Validate(); err != nil {
 		panic(err)
 	}
 
-	// wait for 10 seconds
-	pterm.Info.Println("Wren AI is starting, please wait for a moment...")
-	url := fmt.Sprintf("http://localhost:%d", uiPort)
-	// wait until checking if CheckWrenAIStarted return without error
-	// if timeout 2 minutes, panic
-	timeoutTime := time.Now().Add(2 * time.Minute)
-	for {
-		if time.Now().After(timeoutTime) {
-			panic("Timeout")
-		}
+	// OpenAI is still embedded directly into the compose/env download step;
+	// every other provider renders its own .env.ai fragment afterwards.
+	openaiApiKey := ""
+	openaiGenerationModel := ""
+	if openaiProvider, ok := provider.(*providers.OpenAIProvider); ok {
+		openaiApiKey = openaiProvider.APIKey
+		openaiGenerationModel = openaiProvider.Model
+	}
 
-		// check if ui is ready
-		err := utils.CheckUIServiceStarted(url)
-		if err == nil {
-			pterm.Info.Println("UI Service is ready")
-			break
+	// verify the credentials work before spending time bringing up Docker
+	if key, model := credentialsToVerify(provider); key != "" {
+		pterm.Info.Println("Verifying credentials with", provider.Name())
+		if err := utils.VerifyLLMCredentials(provider.Name(), key, model); err != nil {
+			panic(err)
 		}
-		time.Sleep(5 * time.Second)
 	}
 
-	for {
-		if time.Now().After(timeoutTime) {
-			panic("Timeout")
-		}
+	// ask for telemetry consent
+	pterm.Print("\n")
+	telemetryEnabled, err := evaluateTelemetryPreferences()
 
-		// check if ai service is ready
-		err := utils.CheckAIServiceStarted(aiPort)
-		if err == nil {
-			pterm.Info.Println("AI Service is Ready")
-			break
-		}
-		time.Sleep(5 * time.Second)
+	if err != nil {
+		pterm.Error.Println("Failed to get API key")
+		panic(err)
 	}
 
-	// open browser
-	pterm.Info.Println("Opening browser")
-	utils.Openbrowser(url)
+	// find an available port
+	params := launchParams{
+		llmProvider:           provider.Name(),
+		openaiApiKey:          openaiApiKey,
+		openaiGenerationModel: openaiGenerationModel,
+		telemetryEnabled:      telemetryEnabled,
+		uiPort:                utils.FindAvailablePort(3000),
+		aiPort:                utils.FindAvailablePort(5555),
+		provider:              provider,
+		gpu:                   opts.GPU,
+		healthOpts:            opts.Health,
+		healthzPort:           opts.HealthzPort,
+	}
+
+	if err := runLaunchSequence(params, projectDir); err != nil {
+		panic(err)
+	}
 
-	pterm.Info.Println("You can now safely close this terminal window")
 	fmt.Scanf("h")
 }
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Canner/WrenAI/wren-launcher/config"
+	"github.com/Canner/WrenAI/wren-launcher/providers"
+	utils "github.com/Canner/WrenAI/wren-launcher/utils"
+	"github.com/pterm/pterm"
+)
+
+// LaunchFromConfig drives the whole setup from a config file instead of the
+// interactive promptui flow, so the launcher can run unattended in CI,
+// devcontainers, and headless servers. When dryRun is true, the resolved
+// config is printed and nothing is changed on disk or in Docker.
+func LaunchFromConfig(configPath string, dryRun bool) error {
+	printHeader()
+
+	cfg, err := config.LoadLaunchConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+
+	if dryRun {
+		pterm.Info.Println("Resolved configuration (dry run, nothing was changed):")
+		fmt.Println(cfg.String())
+		return nil
+	}
+
+	provider, err := buildProviderFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+	if err := provider.Validate(); err != nil {
+		return fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+
+	pterm.Info.Println("Preparing project directory")
+	projectDir := prepareProjectDir()
+
+	if err := materializeGalleryModels(cfg, projectDir); err != nil {
+		return err
+	}
+
+	// OpenAI is still embedded directly into the compose/env download step;
+	// every other provider renders its own .env.ai fragment afterwards, same
+	// as the interactive Launch flow.
+	openaiApiKey := ""
+	openaiGenerationModel := ""
+	if openaiProvider, ok := provider.(*providers.OpenAIProvider); ok {
+		openaiApiKey = openaiProvider.APIKey
+		openaiGenerationModel = openaiProvider.Model
+	}
+
+	if key, model := credentialsToVerify(provider); key != "" {
+		pterm.Info.Println("Verifying credentials with", cfg.LLMProvider)
+		if err := utils.VerifyLLMCredentials(cfg.LLMProvider, key, model); err != nil {
+			return err
+		}
+
+		if cfg.EmbeddingModel != "" {
+			pterm.Info.Println("Verifying embedding model with", cfg.LLMProvider)
+			if err := utils.VerifyEmbeddingModel(cfg.LLMProvider, key, cfg.EmbeddingModel); err != nil {
+				return err
+			}
+		}
+	}
+
+	uiPort := cfg.UIPort
+	if uiPort == 0 {
+		uiPort = utils.FindAvailablePort(3000)
+	}
+	aiPort := cfg.AIPort
+	if aiPort == 0 {
+		aiPort = utils.FindAvailablePort(5555)
+	}
+
+	params := launchParams{
+		llmProvider:           provider.Name(),
+		openaiApiKey:          openaiApiKey,
+		openaiGenerationModel: openaiGenerationModel,
+		telemetryEnabled:      !cfg.DisableTelemetry,
+		uiPort:                uiPort,
+		aiPort:                aiPort,
+		gpu:                   cfg.GPU,
+		provider:              provider,
+		extraEnv:              func(projectDir string) error { return appendGalleryEnv(cfg, projectDir) },
+	}
+
+	return runLaunchSequence(params, projectDir)
+}
+
+// buildProviderFromConfig constructs the registered Provider matching
+// cfg.LLMProvider and populates it from the config's fields, mirroring what
+// the interactive Prompt() flow would have gathered.
+func buildProviderFromConfig(cfg *config.LaunchConfig) (providers.Provider, error) {
+	switch cfg.LLMProvider {
+	case "OpenAI":
+		return &providers.OpenAIProvider{APIKey: cfg.APIKey, Model: cfg.GenerationModel}, nil
+	case "Anthropic":
+		return &providers.AnthropicProvider{APIKey: cfg.APIKey, Model: cfg.GenerationModel}, nil
+	case "Azure OpenAI":
+		return &providers.AzureOpenAIProvider{
+			Endpoint:   cfg.Endpoint,
+			APIKey:     cfg.APIKey,
+			Deployment: cfg.Deployment,
+			APIVersion: cfg.APIVersion,
+		}, nil
+	case "Ollama":
+		return &providers.OllamaProvider{BaseURL: cfg.BaseURL, Model: cfg.GenerationModel}, nil
+	case "Custom (OpenAI-compatible)":
+		return &providers.GenericOpenAICompatibleProvider{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey, Model: cfg.GenerationModel}, nil
+	default:
+		return nil, fmt.Errorf("unknown llmProvider %q", cfg.LLMProvider)
+	}
+}
+
+// appendGalleryEnv appends the config's embedding model and gallery preset
+// names to .env.ai, after the chosen provider has written its own fragment.
+// Without this, EmbeddingModel and Gallery are parsed but never reach
+// Docker, and a gallery-only config produces no usable LLM configuration.
+func appendGalleryEnv(cfg *config.LaunchConfig, projectDir string) error {
+	if cfg.EmbeddingModel == "" && len(cfg.Gallery) == 0 {
+		return nil
+	}
+
+	var content strings.Builder
+	if cfg.EmbeddingModel != "" {
+		fmt.Fprintf(&content, "EMBEDDING_MODEL=%s\n", cfg.EmbeddingModel)
+	}
+	if len(cfg.Gallery) > 0 {
+		names := make([]string, len(cfg.Gallery))
+		for i, model := range cfg.Gallery {
+			names[i] = model.Name
+		}
+		fmt.Fprintf(&content, "GALLERY_MODELS=%s\n", strings.Join(names, ","))
+	}
+
+	envFilePath := path.Join(projectDir, ".env.ai")
+	f, err := os.OpenFile(envFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to append gallery config to %s: %w", envFilePath, err)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(content.String())
+	return err
+}
+
+// materializeGalleryModels writes one YAML file per gallery preset under
+// projectDir, mirroring how a config-driven model gallery resolves named
+// presets into concrete per-model files on disk.
+func materializeGalleryModels(cfg *config.LaunchConfig, projectDir string) error {
+	if len(cfg.Gallery) == 0 {
+		return nil
+	}
+
+	modelsDir := path.Join(projectDir, "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create gallery models directory: %w", err)
+	}
+
+	for _, model := range cfg.Gallery {
+		modelPath := path.Join(modelsDir, model.Name+".yaml")
+		content := fmt.Sprintf("name: %s\nprovider: %s\nmodel: %s\n", model.Name, model.Provider, model.Model)
+		if model.Template != "" {
+			content += fmt.Sprintf("template: %s\n", model.Template)
+		}
+		for key, value := range model.Params {
+			content += fmt.Sprintf("%s: %s\n", key, value)
+		}
+
+		if err := os.WriteFile(modelPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write gallery model %s: %w", model.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ConfigInit writes a starter config file to path for users to customize
+// before running `wren-launcher --config`. It refuses to overwrite an
+// existing file.
+func ConfigInit(path string) error {
+	if path == "" {
+		path = "wren-launcher.config.yaml"
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config file %s already exists", path)
+	}
+
+	if err := config.WriteStarterConfig(path); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Wrote starter config to %s\n", path)
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Canner/WrenAI/wren-launcher/healthcheck"
+	utils "github.com/Canner/WrenAI/wren-launcher/utils"
+	"github.com/manifoldco/promptui"
+	"github.com/pterm/pterm"
+)
+
+// minFreeDiskBytes is a rough floor for pulling all of Wren AI's images.
+const minFreeDiskBytes = 5 * 1024 * 1024 * 1024 // 5 GiB
+
+const projectName = "wrenai"
+
+// Down stops the Wren AI containers while preserving their volumes, so a
+// later `up` resumes from where the user left off.
+func Down() error {
+	projectDir := prepareProjectDir()
+	pterm.Info.Println("Stopping Wren AI containers")
+	return utils.StopDockerCompose(projectName, projectDir)
+}
+
+// Logs tails the logs for the given service (or every service, if empty).
+func Logs(service string, follow bool) error {
+	projectDir := prepareProjectDir()
+	return utils.TailDockerComposeLogs(projectName, projectDir, service, follow)
+}
+
+// Upgrade backs up ~/.wrenai, pulls newer images for the current compose
+// file, and brings the stack back up.
+func Upgrade() error {
+	projectDir := prepareProjectDir()
+
+	backupDir := projectDir + ".bak-" + time.Now().Format("20060102150405")
+	pterm.Info.Printf("Backing up %s to %s\n", projectDir, backupDir)
+	if err := utils.BackupProjectDir(projectDir, backupDir); err != nil {
+		return fmt.Errorf("failed to back up %s before upgrading: %w", projectDir, err)
+	}
+
+	pterm.Info.Println("Pulling newer Wren AI images")
+	if err := utils.PullDockerComposeImages(projectName, projectDir); err != nil {
+		return err
+	}
+
+	pterm.Info.Println("Restarting Wren AI with the new images")
+	return utils.RunDockerCompose(projectName, projectDir, "")
+}
+
+// Reset destructively wipes ~/.wrenai, including the compose files, .env.ai,
+// and any gallery model configs. It refuses to run without confirmation.
+func Reset(confirmed bool) error {
+	projectDir := prepareProjectDir()
+
+	if !confirmed {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("This will permanently delete %s, including all data. Continue", projectDir),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return errors.New("reset cancelled")
+		}
+	}
+
+	pterm.Info.Println("Stopping Wren AI containers")
+	if err := utils.StopDockerCompose(projectName, projectDir); err != nil {
+		pterm.Warning.Println("Failed to stop containers cleanly, continuing with reset:", err)
+	}
+
+	pterm.Info.Printf("Removing %s\n", projectDir)
+	return utils.RemoveProjectDir(projectDir)
+}
+
+// Doctor diagnoses common setup problems: the Docker daemon, port conflicts,
+// whether .env.ai exists (its contents are not checked), disk space, and
+// container health. health controls how long and how often the container
+// probes retry before being reported down.
+func Doctor(health healthcheck.Options) error {
+	projectDir := prepareProjectDir()
+	pterm.DefaultSection.Println("Wren AI doctor")
+
+	if _, err := utils.CheckDockerDaemonRunning(); err != nil {
+		pterm.Error.Println("Docker daemon: not running -", err)
+	} else {
+		pterm.Success.Println("Docker daemon: running")
+	}
+
+	for _, port := range []int{3000, 5555} {
+		if utils.CheckPortInUse(port) {
+			pterm.Warning.Printf("Port %d: already in use\n", port)
+		} else {
+			pterm.Success.Printf("Port %d: available\n", port)
+		}
+	}
+
+	envFilePath := path.Join(projectDir, ".env.ai")
+	if _, err := os.Stat(envFilePath); err != nil {
+		pterm.Warning.Printf(".env.ai: not found at %s\n", envFilePath)
+	} else {
+		pterm.Success.Printf(".env.ai: found at %s\n", envFilePath)
+	}
+
+	if avail, err := utils.CheckDiskSpace(projectDir); err != nil {
+		pterm.Warning.Println("Disk space: could not be determined -", err)
+	} else if avail < minFreeDiskBytes {
+		pterm.Warning.Printf("Disk space: only %.1f GiB free under %s, pulling images may fail\n", float64(avail)/(1<<30), projectDir)
+	} else {
+		pterm.Success.Printf("Disk space: %.1f GiB free under %s\n", float64(avail)/(1<<30), projectDir)
+	}
+
+	opts := StatusOptions{
+		UIPort: 3000,
+		AIPort: 5555,
+		Health: health,
+	}
+	for _, result := range buildStatusRegistry(opts).RunAll() {
+		pterm.Println(result.String())
+	}
+
+	return nil
+}
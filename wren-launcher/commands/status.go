@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Canner/WrenAI/wren-launcher/healthcheck"
+	"github.com/pterm/pterm"
+)
+
+// StatusOptions configures the `wren-launcher status` and `doctor` commands.
+type StatusOptions struct {
+	UIPort         int
+	AIPort         int
+	QdrantPort     int
+	WrenEnginePort int
+	IbisPort       int
+	Health         healthcheck.Options
+	JSON           bool
+}
+
+// buildStatusRegistry wires up the same probes used during Launch, in
+// dependency order, so `status` reports the same picture of readiness.
+func buildStatusRegistry(opts StatusOptions) *healthcheck.Registry {
+	uiURL := fmt.Sprintf("http://localhost:%d", opts.UIPort)
+
+	params := launchParams{
+		uiPort:         opts.UIPort,
+		aiPort:         opts.AIPort,
+		qdrantPort:     opts.QdrantPort,
+		wrenEnginePort: opts.WrenEnginePort,
+		ibisPort:       opts.IbisPort,
+		healthOpts:     opts.Health,
+	}
+
+	return buildServiceRegistry(params, uiURL)
+}
+
+// Status runs every probe in dependency order, each retrying until healthy or
+// out of budget, and then reports the results, either as a pterm summary or,
+// with opts.JSON, as a single JSON document for scripting. Nothing is printed
+// until every probe has finished, and the summary is not updated afterwards.
+func Status(opts StatusOptions) error {
+	registry := buildStatusRegistry(opts)
+	results := registry.RunAll()
+
+	if opts.JSON {
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	pterm.DefaultSection.Println("Wren AI service status")
+	for _, result := range results {
+		if result.Status == healthcheck.StatusHealthy {
+			pterm.Success.Println(result.String())
+		} else {
+			pterm.Error.Println(result.String())
+		}
+	}
+
+	return nil
+}
+
+// ServeHealthz runs every probe once and then serves the results on addr
+// (e.g. ":8081") until the process exits or the listener fails. Used by
+// `wren-launcher status --serve` for orchestrators that want to poll
+// /healthz instead of shelling out repeatedly.
+func ServeHealthz(opts StatusOptions, addr string) error {
+	registry := buildStatusRegistry(opts)
+	registry.RunAll()
+	pterm.Info.Printf("Serving health status on http://localhost%s/healthz\n", addr)
+	return registry.Serve(addr)
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GalleryModel is a named preset that gets materialized into .env.ai and a
+// per-model config file under ~/.wrenai, similar to how LocalAI resolves a
+// model gallery entry into a concrete backend config.
+type GalleryModel struct {
+	Name     string            `yaml:"name" json:"name"`
+	Provider string            `yaml:"provider" json:"provider"`
+	Model    string            `yaml:"model" json:"model"`
+	Params   map[string]string `yaml:"params,omitempty" json:"params,omitempty"`
+	Template string            `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+// LaunchConfig is the schema accepted by `wren-launcher --config`. It covers
+// everything the interactive prompts would otherwise ask for, so the launcher
+// can be driven non-interactively in CI, devcontainers, or headless servers.
+type LaunchConfig struct {
+	// LLMProvider selects which provider in the providers registry to use:
+	// "OpenAI", "Anthropic", "Azure OpenAI", "Ollama", or
+	// "Custom (OpenAI-compatible)".
+	LLMProvider     string `yaml:"llmProvider" json:"llmProvider"`
+	APIKey          string `yaml:"apiKey,omitempty" json:"apiKey,omitempty"`
+	GenerationModel string `yaml:"generationModel,omitempty" json:"generationModel,omitempty"`
+	EmbeddingModel  string `yaml:"embeddingModel,omitempty" json:"embeddingModel,omitempty"`
+	// Endpoint, Deployment, and APIVersion are only used by Azure OpenAI.
+	Endpoint   string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	Deployment string `yaml:"deployment,omitempty" json:"deployment,omitempty"`
+	APIVersion string `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`
+	// BaseURL is used by Ollama and the generic OpenAI-compatible provider.
+	BaseURL          string         `yaml:"baseUrl,omitempty" json:"baseUrl,omitempty"`
+	DisableTelemetry bool           `yaml:"disableTelemetry" json:"disableTelemetry"`
+	UIPort           int            `yaml:"uiPort,omitempty" json:"uiPort,omitempty"`
+	AIPort           int            `yaml:"aiPort,omitempty" json:"aiPort,omitempty"`
+	// GPU selects a Docker Compose accelerator profile: "auto" (default),
+	// "none", "cuda", or "rocm".
+	GPU     string         `yaml:"gpu,omitempty" json:"gpu,omitempty"`
+	Gallery []GalleryModel `yaml:"gallery,omitempty" json:"gallery,omitempty"`
+}
+
+// LoadLaunchConfig reads a YAML or JSON config file from path, picking the
+// decoder based on the file extension.
+func LoadLaunchConfig(path string) (*LaunchConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	cfg := &LaunchConfig{}
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(raw, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s as JSON: %w", path, err)
+		}
+	} else {
+		if err := yaml.Unmarshal(raw, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s as YAML: %w", path, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the config has enough information to launch Wren AI
+// without prompting the user for anything else.
+func (c *LaunchConfig) Validate() error {
+	if c.LLMProvider == "" {
+		return errors.New("llmProvider is required")
+	}
+
+	switch c.LLMProvider {
+	case "OpenAI", "Anthropic":
+		if c.APIKey == "" {
+			return fmt.Errorf("apiKey is required when llmProvider is %s", c.LLMProvider)
+		}
+		if c.GenerationModel == "" {
+			return fmt.Errorf("generationModel is required when llmProvider is %s", c.LLMProvider)
+		}
+	case "Azure OpenAI":
+		if c.Endpoint == "" {
+			return errors.New("endpoint is required when llmProvider is Azure OpenAI")
+		}
+		if c.APIKey == "" {
+			return errors.New("apiKey is required when llmProvider is Azure OpenAI")
+		}
+		if c.Deployment == "" {
+			return errors.New("deployment is required when llmProvider is Azure OpenAI")
+		}
+		if c.APIVersion == "" {
+			return errors.New("apiVersion is required when llmProvider is Azure OpenAI")
+		}
+	case "Ollama":
+		if c.BaseURL == "" {
+			return errors.New("baseUrl is required when llmProvider is Ollama")
+		}
+		if c.GenerationModel == "" {
+			return errors.New("generationModel is required when llmProvider is Ollama")
+		}
+	case "Custom (OpenAI-compatible)":
+		if c.BaseURL == "" {
+			return errors.New("baseUrl is required when llmProvider is Custom (OpenAI-compatible)")
+		}
+		if c.GenerationModel == "" {
+			return errors.New("generationModel is required when llmProvider is Custom (OpenAI-compatible)")
+		}
+	default:
+		return fmt.Errorf("unknown llmProvider %q", c.LLMProvider)
+	}
+
+	for i, model := range c.Gallery {
+		if model.Name == "" {
+			return fmt.Errorf("gallery[%d]: name is required", i)
+		}
+		if model.Provider == "" {
+			return fmt.Errorf("gallery[%d]: provider is required", i)
+		}
+		if model.Model == "" {
+			return fmt.Errorf("gallery[%d]: model is required", i)
+		}
+	}
+
+	return nil
+}
+
+// String renders the resolved config as YAML, used by `--dry-run` to show
+// the user exactly what would be applied without touching Docker. APIKey is
+// masked so a CI log capturing --dry-run output doesn't leak it.
+func (c *LaunchConfig) String() string {
+	redacted := *c
+	if redacted.APIKey != "" {
+		redacted.APIKey = "***"
+	}
+
+	out, err := yaml.Marshal(&redacted)
+	if err != nil {
+		return fmt.Sprintf("<failed to render config: %v>", err)
+	}
+	return string(out)
+}
+
+const starterConfigTemplate = `# Wren AI launcher config
+# See https://docs.getwren.ai/installation/custom_llm for provider details.
+llmProvider: OpenAI
+apiKey: sk-replace-me
+generationModel: gpt-4o
+# embeddingModel: text-embedding-3-large
+disableTelemetry: false
+# uiPort: 3000
+# aiPort: 5555
+# gallery:
+#   - name: local-llama
+#     provider: Ollama
+#     model: llama3
+#     params:
+#       baseUrl: http://localhost:11434
+`
+
+// WriteStarterConfig writes a commented starter config file to path, used by
+// `wren-launcher config init`.
+func WriteStarterConfig(path string) error {
+	return os.WriteFile(path, []byte(starterConfigTemplate), 0644)
+}
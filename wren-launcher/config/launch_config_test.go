@@ -0,0 +1,77 @@
+package config
+
+import "testing"
+
+func TestLaunchConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     LaunchConfig
+		wantErr bool
+	}{
+		{
+			name: "valid OpenAI config",
+			cfg:  LaunchConfig{LLMProvider: "OpenAI", APIKey: "sk-test", GenerationModel: "gpt-4o"},
+		},
+		{
+			name:    "OpenAI missing api key",
+			cfg:     LaunchConfig{LLMProvider: "OpenAI", GenerationModel: "gpt-4o"},
+			wantErr: true,
+		},
+		{
+			name: "valid Azure OpenAI config",
+			cfg: LaunchConfig{
+				LLMProvider: "Azure OpenAI",
+				Endpoint:    "https://example.openai.azure.com",
+				APIKey:      "azure-key",
+				Deployment:  "gpt-4o-deployment",
+				APIVersion:  "2024-02-01",
+			},
+		},
+		{
+			name:    "Azure OpenAI missing deployment",
+			cfg:     LaunchConfig{LLMProvider: "Azure OpenAI", Endpoint: "https://example.openai.azure.com", APIKey: "azure-key", APIVersion: "2024-02-01"},
+			wantErr: true,
+		},
+		{
+			name: "valid Ollama config",
+			cfg:  LaunchConfig{LLMProvider: "Ollama", BaseURL: "http://localhost:11434", GenerationModel: "llama3"},
+		},
+		{
+			name:    "Ollama missing base url",
+			cfg:     LaunchConfig{LLMProvider: "Ollama", GenerationModel: "llama3"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider",
+			cfg:     LaunchConfig{LLMProvider: "Not A Provider"},
+			wantErr: true,
+		},
+		{
+			name:    "missing provider",
+			cfg:     LaunchConfig{},
+			wantErr: true,
+		},
+		{
+			name: "gallery entry missing model",
+			cfg: LaunchConfig{
+				LLMProvider:     "OpenAI",
+				APIKey:          "sk-test",
+				GenerationModel: "gpt-4o",
+				Gallery:         []GalleryModel{{Name: "local-llama", Provider: "Ollama"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatalf("Validate() = nil, expected an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate() returned unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package healthcheck
+
+import "time"
+
+// Options bundles the CLI-tunable knobs that used to be hardcoded in each
+// caller: how long to wait overall, how long a single Check gets, and how
+// often to retry.
+type Options struct {
+	// Timeout is the overall budget for a probe to become healthy.
+	Timeout time.Duration
+	// Interval is how often Check is retried.
+	Interval time.Duration
+	// ProbeTimeout bounds a single Check call. Defaults to Interval when unset.
+	ProbeTimeout time.Duration
+	// Retries overrides how many retries follow the first attempt. When zero,
+	// it's derived from Timeout/Interval so the overall budget above is still
+	// honored.
+	Retries int
+}
+
+// DefaultOptions matches the launcher's historical behavior: a 2-minute
+// overall budget, checking every 5 seconds.
+func DefaultOptions() Options {
+	return Options{
+		Timeout:  2 * time.Minute,
+		Interval: 5 * time.Second,
+	}
+}
+
+// NewProbe builds a Probe named name, depending on dependsOn, that calls
+// check using the given Options.
+func NewProbe(name string, dependsOn []string, check func() error, opts Options) *Probe {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 2 * time.Minute
+	}
+
+	probeTimeout := opts.ProbeTimeout
+	if probeTimeout == 0 {
+		probeTimeout = interval
+	}
+
+	retries := opts.Retries
+	if retries == 0 {
+		retries = int(timeout / interval)
+	}
+
+	return &Probe{
+		Name:      name,
+		DependsOn: dependsOn,
+		Interval:  interval,
+		Timeout:   probeTimeout,
+		Retries:   retries,
+		Check:     check,
+	}
+}
@@ -0,0 +1,131 @@
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the outcome of the most recent run of a Probe.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusHealthy Status = "healthy"
+	StatusFailed  Status = "failed"
+)
+
+// Probe models a single dependent service (UI, AI, Qdrant, Wren Engine, Ibis
+// server, ...) that must become healthy before the launcher can consider
+// Wren AI up.
+type Probe struct {
+	// Name is the service name shown in the status dashboard.
+	Name string
+	// DependsOn lists probe names that must be healthy before this probe
+	// starts running, so e.g. the AI service isn't probed before Qdrant is up.
+	DependsOn []string
+	// Interval is how often Check is called while waiting for the probe to
+	// become healthy.
+	Interval time.Duration
+	// Timeout bounds a single call to Check; a Check that doesn't return
+	// within Timeout counts as a failed attempt.
+	Timeout time.Duration
+	// Retries is how many failed attempts are tolerated before the probe is
+	// reported failed.
+	Retries int
+	// Backoff is added to Interval after each failed attempt, up to
+	// MaxInterval.
+	Backoff time.Duration
+	// MaxInterval caps how large Interval is allowed to grow via Backoff. It
+	// defaults to Interval (i.e. no growth) when unset.
+	MaxInterval time.Duration
+	// Check performs one health check and returns an error describing why
+	// the service isn't ready yet.
+	Check func() error
+
+	status        Status
+	lastErr       error
+	lastCheckedAt time.Time
+}
+
+// Result is a point-in-time snapshot of a probe's status, used for both the
+// pterm dashboard and the `--json`/`/healthz` output.
+type Result struct {
+	Name        string    `json:"name"`
+	Status      Status    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	LastChecked time.Time `json:"lastChecked"`
+}
+
+// Run blocks until the probe reports healthy or its retry budget is
+// exhausted, sleeping Interval (growing by Backoff on each failure, capped at
+// MaxInterval) between attempts. Each attempt is itself bounded by Timeout.
+func (p *Probe) Run() Result {
+	interval := p.Interval
+	maxInterval := p.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = p.Interval
+	}
+	attempts := 0
+
+	for {
+		p.lastCheckedAt = time.Now()
+		p.lastErr = p.runCheck()
+		attempts++
+
+		if p.lastErr == nil {
+			p.status = StatusHealthy
+			return p.result()
+		}
+
+		if attempts > p.Retries {
+			p.status = StatusFailed
+			return p.result()
+		}
+
+		time.Sleep(interval)
+		interval += p.Backoff
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// runCheck calls Check and enforces Timeout around it. The check goroutine
+// is left to finish on its own if it overruns (Check has no cancellation
+// signal to honor), but the buffered channel means it doesn't leak blocked.
+func (p *Probe) runCheck() error {
+	if p.Timeout <= 0 {
+		return p.Check()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- p.Check() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(p.Timeout):
+		return fmt.Errorf("%s: check did not complete within %s", p.Name, p.Timeout)
+	}
+}
+
+func (p *Probe) result() Result {
+	res := Result{
+		Name:        p.Name,
+		Status:      p.status,
+		LastChecked: p.lastCheckedAt,
+	}
+	if p.lastErr != nil {
+		res.Error = p.lastErr.Error()
+	}
+	return res
+}
+
+// String renders a Result as a single human-readable line for the pterm
+// dashboard.
+func (r Result) String() string {
+	if r.Status == StatusHealthy {
+		return fmt.Sprintf("%-12s %s", r.Name, r.Status)
+	}
+	return fmt.Sprintf("%-12s %s (%s)", r.Name, r.Status, r.Error)
+}
@@ -0,0 +1,135 @@
+package healthcheck
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry runs a set of Probes in dependency order and keeps their latest
+// Results around for the status dashboard and the /healthz endpoint.
+//
+// RunAll writes to results while Serve's handler reads from it concurrently
+// on another goroutine (polling /healthz while the stack is still coming
+// up is the point of --healthz-port), so access goes through resultsMu.
+type Registry struct {
+	probes    map[string]*Probe
+	results   map[string]Result
+	resultsMu sync.RWMutex
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		probes:  map[string]*Probe{},
+		results: map[string]Result{},
+	}
+}
+
+// Add registers a probe. Panics on duplicate names since that indicates a
+// programming error in how the launcher wired its probes.
+func (r *Registry) Add(p *Probe) {
+	if _, exists := r.probes[p.Name]; exists {
+		panic("healthcheck: duplicate probe name " + p.Name)
+	}
+	p.status = StatusPending
+	r.probes[p.Name] = p
+	// record the pending result so /healthz doesn't report healthy before
+	// every probe has run
+	r.setResult(p.Name, p.result())
+}
+
+// RunAll runs every probe in dependency order, skipping (and marking failed)
+// any probe whose dependencies didn't become healthy.
+func (r *Registry) RunAll() []Result {
+	order := r.topoSort()
+	results := make([]Result, 0, len(order))
+
+	for _, name := range order {
+		probe := r.probes[name]
+
+		if !r.dependenciesHealthy(probe) {
+			probe.status = StatusFailed
+			res := probe.result()
+			r.setResult(name, res)
+			results = append(results, res)
+			continue
+		}
+
+		res := probe.Run()
+		r.setResult(name, res)
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// Results returns the latest known Result per probe, sorted by name, for
+// stable /healthz output.
+func (r *Registry) Results() []Result {
+	r.resultsMu.RLock()
+	defer r.resultsMu.RUnlock()
+
+	results := make([]Result, 0, len(r.results))
+	for _, res := range r.results {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+func (r *Registry) setResult(name string, res Result) {
+	r.resultsMu.Lock()
+	defer r.resultsMu.Unlock()
+	r.results[name] = res
+}
+
+func (r *Registry) getResult(name string) Result {
+	r.resultsMu.RLock()
+	defer r.resultsMu.RUnlock()
+	return r.results[name]
+}
+
+func (r *Registry) dependenciesHealthy(p *Probe) bool {
+	for _, dep := range p.DependsOn {
+		if r.getResult(dep).Status != StatusHealthy {
+			return false
+		}
+	}
+	return true
+}
+
+// topoSort orders probes so each one comes after everything it DependsOn.
+// Probes with no relationship are ordered by name for determinism.
+func (r *Registry) topoSort() []string {
+	visited := map[string]bool{}
+	var order []string
+
+	names := make([]string, 0, len(r.probes))
+	for name := range r.probes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		probe, ok := r.probes[name]
+		if !ok {
+			return
+		}
+		for _, dep := range probe.DependsOn {
+			visit(dep)
+		}
+		order = append(order, name)
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+
+	return order
+}
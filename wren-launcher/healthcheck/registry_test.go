@@ -0,0 +1,77 @@
+package healthcheck
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newNoopProbe(name string, dependsOn []string) *Probe {
+	return &Probe{
+		Name:      name,
+		DependsOn: dependsOn,
+		Check:     func() error { return nil },
+	}
+}
+
+func TestTopoSortOrdersDependenciesFirst(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(newNoopProbe("ui", []string{"ai"}))
+	registry.Add(newNoopProbe("ai", []string{"qdrant", "wren-engine", "ibis"}))
+	registry.Add(newNoopProbe("qdrant", nil))
+	registry.Add(newNoopProbe("wren-engine", nil))
+	registry.Add(newNoopProbe("ibis", nil))
+
+	order := registry.topoSort()
+
+	position := make(map[string]int, len(order))
+	for i, name := range order {
+		position[name] = i
+	}
+
+	for _, dep := range []string{"qdrant", "wren-engine", "ibis"} {
+		if position[dep] >= position["ai"] {
+			t.Errorf("expected %s before ai, got order %v", dep, order)
+		}
+	}
+	if position["ai"] >= position["ui"] {
+		t.Errorf("expected ai before ui, got order %v", order)
+	}
+}
+
+func TestTopoSortIsDeterministicForIndependentProbes(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(newNoopProbe("wren-engine", nil))
+	registry.Add(newNoopProbe("ibis", nil))
+	registry.Add(newNoopProbe("qdrant", nil))
+
+	got := registry.topoSort()
+	want := []string{"ibis", "qdrant", "wren-engine"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topoSort() = %v, want %v", got, want)
+	}
+}
+
+func TestRunAllSkipsProbesWithUnhealthyDependencies(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(&Probe{
+		Name:  "qdrant",
+		Check: func() error { return errors.New("connection refused") },
+	})
+	registry.Add(newNoopProbe("ai", []string{"qdrant"}))
+
+	results := registry.RunAll()
+
+	byName := make(map[string]Result, len(results))
+	for _, res := range results {
+		byName[res.Name] = res
+	}
+
+	if byName["qdrant"].Status != StatusFailed {
+		t.Fatalf("expected qdrant to fail, got %s", byName["qdrant"].Status)
+	}
+	if byName["ai"].Status != StatusFailed {
+		t.Errorf("expected ai to be skipped as failed when qdrant is unhealthy, got %s", byName["ai"].Status)
+	}
+}
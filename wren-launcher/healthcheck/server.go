@@ -0,0 +1,38 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Serve exposes the registry's latest results on a side HTTP port so
+// orchestrators can poll readiness without shelling out to the launcher
+// binary. It blocks until the listener fails and is meant to be run in its
+// own goroutine.
+func (r *Registry) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", r.handleHealthz)
+	return http.ListenAndServe(addr, mux)
+}
+
+func (r *Registry) handleHealthz(w http.ResponseWriter, req *http.Request) {
+	results := r.Results()
+
+	allHealthy := len(results) > 0
+	for _, res := range results {
+		if res.Status != StatusHealthy {
+			allHealthy = false
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !allHealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"healthy": allHealthy,
+		"probes":  results,
+	})
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Canner/WrenAI/wren-launcher/commands"
+	"github.com/Canner/WrenAI/wren-launcher/healthcheck"
+	"github.com/urfave/cli/v2"
+)
+
+// healthFlags are the probe-tuning flags shared by `up`, `status`, and
+// `doctor`, so all three agree on how long to wait and how often to retry.
+// defaultTimeout lets `doctor` default to a short budget (one retry per probe
+// at the default interval) instead of the multi-minute budget `up` needs
+// while Docker images are still pulling.
+func healthFlags(defaultTimeout time.Duration) []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{Name: "health-timeout", Value: defaultTimeout, Usage: "overall budget for every service to become healthy"},
+		&cli.DurationFlag{Name: "probe-interval", Value: 5 * time.Second, Usage: "how often to retry a probe that isn't healthy yet"},
+		&cli.DurationFlag{Name: "probe-timeout", Usage: "how long a single probe attempt gets before it's considered failed (defaults to probe-interval)"},
+		&cli.IntFlag{Name: "retries", Usage: "number of retries per probe (defaults to health-timeout / probe-interval)"},
+	}
+}
+
+func healthOptionsFromFlags(c *cli.Context) healthcheck.Options {
+	return healthcheck.Options{
+		Timeout:      c.Duration("health-timeout"),
+		Interval:     c.Duration("probe-interval"),
+		ProbeTimeout: c.Duration("probe-timeout"),
+		Retries:      c.Int("retries"),
+	}
+}
+
+func main() {
+	app := &cli.App{
+		Name:  "wren-launcher",
+		Usage: "install, launch, and manage a local Wren AI stack",
+		Commands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "launch Wren AI (the default when no command is given)",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "config", Usage: "path to a launch config file to run non-interactively"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "print the resolved config instead of launching, requires --config"},
+					&cli.StringFlag{Name: "gpu", Value: "auto", Usage: "accelerator profile: auto, none, cuda, rocm"},
+					&cli.IntFlag{Name: "healthz-port", Usage: "expose live probe results on http://localhost:<port>/healthz while waiting for the stack to come up"},
+				}, healthFlags(2*time.Minute)...),
+				Action: func(c *cli.Context) error {
+					configPath := c.String("config")
+					if configPath == "" {
+						if c.Bool("dry-run") {
+							return fmt.Errorf("--dry-run requires --config")
+						}
+						commands.Launch(commands.LaunchOptions{
+							GPU:         c.String("gpu"),
+							Health:      healthOptionsFromFlags(c),
+							HealthzPort: c.Int("healthz-port"),
+						})
+						return nil
+					}
+					return commands.LaunchFromConfig(configPath, c.Bool("dry-run"))
+				},
+			},
+			{
+				Name:   "down",
+				Usage:  "stop the Wren AI containers, keeping their volumes",
+				Action: func(c *cli.Context) error { return commands.Down() },
+			},
+			{
+				Name:      "logs",
+				Usage:     "tail logs for a Wren AI service",
+				ArgsUsage: "[service]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "follow", Aliases: []string{"f"}, Usage: "keep streaming new log lines"},
+				},
+				Action: func(c *cli.Context) error {
+					return commands.Logs(c.Args().First(), c.Bool("follow"))
+				},
+			},
+			{
+				Name:   "upgrade",
+				Usage:  "back up ~/.wrenai, pull newer images, and restart the stack",
+				Action: func(c *cli.Context) error { return commands.Upgrade() },
+			},
+			{
+				Name:  "reset",
+				Usage: "destructively wipe ~/.wrenai",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "yes", Usage: "skip the confirmation prompt"},
+				},
+				Action: func(c *cli.Context) error { return commands.Reset(c.Bool("yes")) },
+			},
+			{
+				Name:   "doctor",
+				Usage:  "diagnose the Docker daemon, port conflicts, disk space, and whether .env.ai exists",
+				Flags:  healthFlags(5 * time.Second),
+				Action: func(c *cli.Context) error { return commands.Doctor(healthOptionsFromFlags(c)) },
+			},
+			{
+				Name:  "status",
+				Usage: "check readiness of the running Wren AI services",
+				Flags: append([]cli.Flag{
+					&cli.IntFlag{Name: "ui-port", Value: 3000},
+					&cli.IntFlag{Name: "ai-port", Value: 5555},
+					&cli.IntFlag{Name: "qdrant-port", Value: 6333},
+					&cli.IntFlag{Name: "wren-engine-port", Value: 8080},
+					&cli.IntFlag{Name: "ibis-port", Value: 8000},
+					&cli.BoolFlag{Name: "json", Usage: "print status as JSON instead of a pterm summary"},
+					&cli.BoolFlag{Name: "serve", Usage: "serve results on /healthz instead of checking once and exiting"},
+					&cli.IntFlag{Name: "healthz-port", Value: 8081, Usage: "port to serve /healthz on, with --serve"},
+				}, healthFlags(2*time.Minute)...),
+				Action: func(c *cli.Context) error {
+					opts := commands.StatusOptions{
+						UIPort:         c.Int("ui-port"),
+						AIPort:         c.Int("ai-port"),
+						QdrantPort:     c.Int("qdrant-port"),
+						WrenEnginePort: c.Int("wren-engine-port"),
+						IbisPort:       c.Int("ibis-port"),
+						Health:         healthOptionsFromFlags(c),
+						JSON:           c.Bool("json"),
+					}
+					if c.Bool("serve") {
+						return commands.ServeHealthz(opts, fmt.Sprintf(":%d", c.Int("healthz-port")))
+					}
+					return commands.Status(opts)
+				},
+			},
+			{
+				Name:  "config",
+				Usage: "manage wren-launcher config files",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "init",
+						Usage:     "write a starter launch config file",
+						ArgsUsage: "[path]",
+						Action: func(c *cli.Context) error {
+							return commands.ConfigInit(c.Args().First())
+						},
+					},
+				},
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "gpu", Value: "auto", Usage: "accelerator profile: auto, none, cuda, rocm"},
+		},
+		// running `wren-launcher` with no subcommand preserves the original
+		// interactive behavior
+		Action: func(c *cli.Context) error {
+			commands.Launch(commands.LaunchOptions{GPU: c.String("gpu")})
+			return nil
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+func init() {
+	Register(&AnthropicProvider{})
+}
+
+// AnthropicProvider drives Claude models through the Anthropic API.
+type AnthropicProvider struct {
+	APIKey string
+	Model  string
+}
+
+func (p *AnthropicProvider) Name() string { return "Anthropic" }
+
+func (p *AnthropicProvider) Models() []string {
+	return []string{"claude-3-5-sonnet-latest", "claude-3-5-haiku-latest", "claude-3-opus-latest"}
+}
+
+func (p *AnthropicProvider) Prompt() error {
+	fmt.Println("Please provide your Anthropic API key")
+	fmt.Println("You can find it at https://console.anthropic.com/settings/keys")
+
+	keyPrompt := promptui.Prompt{
+		Label: "Anthropic API key",
+		Validate: func(input string) error {
+			if !strings.HasPrefix(input, "sk-ant-") {
+				return errors.New("invalid API key")
+			}
+			return nil
+		},
+		Mask: '*',
+	}
+
+	key, err := keyPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.APIKey = key
+
+	modelPrompt := promptui.Select{
+		Label: "Select a Claude generation model",
+		Items: p.Models(),
+	}
+
+	_, model, err := modelPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Model = model
+
+	return nil
+}
+
+func (p *AnthropicProvider) Validate() error {
+	if !strings.HasPrefix(p.APIKey, "sk-ant-") {
+		return errors.New("invalid Anthropic API key: expected it to start with \"sk-ant-\"")
+	}
+	if p.Model == "" {
+		return errors.New("generation model is required")
+	}
+	return nil
+}
+
+// RenderEnv appends Anthropic's fragment to .env.ai rather than overwriting
+// it, since utils.PrepareDockerFiles has already written the ports and
+// telemetry settings there.
+func (p *AnthropicProvider) RenderEnv(projectDir string) error {
+	content := fmt.Sprintf("LLM_PROVIDER=anthropic\nANTHROPIC_API_KEY=%s\nGENERATION_MODEL=%s\n", p.APIKey, p.Model)
+	return appendEnvFragment(projectDir, content)
+}
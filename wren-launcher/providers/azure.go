@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+)
+
+func init() {
+	Register(&AzureOpenAIProvider{})
+}
+
+// AzureOpenAIProvider talks to an Azure OpenAI resource, which is addressed
+// by endpoint + deployment name + api-version rather than a bare model name.
+type AzureOpenAIProvider struct {
+	Endpoint   string
+	APIKey     string
+	Deployment string
+	APIVersion string
+}
+
+func (p *AzureOpenAIProvider) Name() string { return "Azure OpenAI" }
+
+func (p *AzureOpenAIProvider) Models() []string { return nil }
+
+func (p *AzureOpenAIProvider) Prompt() error {
+	fmt.Println("Please provide your Azure OpenAI resource endpoint, e.g. https://my-resource.openai.azure.com")
+	endpointPrompt := promptui.Prompt{Label: "Azure OpenAI endpoint"}
+	endpoint, err := endpointPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Endpoint = endpoint
+
+	keyPrompt := promptui.Prompt{Label: "Azure OpenAI API key", Mask: '*'}
+	key, err := keyPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.APIKey = key
+
+	deploymentPrompt := promptui.Prompt{Label: "Azure OpenAI deployment name"}
+	deployment, err := deploymentPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Deployment = deployment
+
+	versionPrompt := promptui.Prompt{Label: "Azure OpenAI API version", Default: "2024-02-15-preview"}
+	version, err := versionPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.APIVersion = version
+
+	return nil
+}
+
+func (p *AzureOpenAIProvider) Validate() error {
+	if p.Endpoint == "" {
+		return errors.New("Azure OpenAI endpoint is required")
+	}
+	if p.APIKey == "" {
+		return errors.New("Azure OpenAI API key is required")
+	}
+	if p.Deployment == "" {
+		return errors.New("Azure OpenAI deployment name is required")
+	}
+	if p.APIVersion == "" {
+		return errors.New("Azure OpenAI API version is required")
+	}
+	return nil
+}
+
+// RenderEnv appends Azure OpenAI's fragment to .env.ai rather than
+// overwriting it, since utils.PrepareDockerFiles has already written the
+// ports and telemetry settings there.
+func (p *AzureOpenAIProvider) RenderEnv(projectDir string) error {
+	content := fmt.Sprintf(
+		"LLM_PROVIDER=azure_openai\nAZURE_OPENAI_ENDPOINT=%s\nAZURE_OPENAI_API_KEY=%s\nAZURE_OPENAI_DEPLOYMENT=%s\nAZURE_OPENAI_API_VERSION=%s\n",
+		p.Endpoint, p.APIKey, p.Deployment, p.APIVersion,
+	)
+	return appendEnvFragment(projectDir, content)
+}
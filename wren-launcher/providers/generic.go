@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+)
+
+func init() {
+	Register(&GenericOpenAICompatibleProvider{})
+}
+
+// GenericOpenAICompatibleProvider covers any endpoint that speaks the OpenAI
+// chat completions API (vLLM, LM Studio, LiteLLM proxies, etc.), identified
+// by a base URL and an optional API key instead of a known provider name.
+type GenericOpenAICompatibleProvider struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+}
+
+func (p *GenericOpenAICompatibleProvider) Name() string { return "Custom (OpenAI-compatible)" }
+
+func (p *GenericOpenAICompatibleProvider) Models() []string { return nil }
+
+func (p *GenericOpenAICompatibleProvider) Prompt() error {
+	urlPrompt := promptui.Prompt{Label: "OpenAI-compatible base URL"}
+	baseURL, err := urlPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.BaseURL = baseURL
+
+	keyPrompt := promptui.Prompt{Label: "API key (leave blank if not required)", Mask: '*'}
+	key, err := keyPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.APIKey = key
+
+	modelPrompt := promptui.Prompt{Label: "Model name"}
+	model, err := modelPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Model = model
+
+	return nil
+}
+
+func (p *GenericOpenAICompatibleProvider) Validate() error {
+	if p.BaseURL == "" {
+		return errors.New("base URL is required")
+	}
+	if p.Model == "" {
+		return errors.New("model name is required")
+	}
+	return nil
+}
+
+// RenderEnv appends this provider's fragment to .env.ai rather than
+// overwriting it, since utils.PrepareDockerFiles has already written the
+// ports and telemetry settings there.
+func (p *GenericOpenAICompatibleProvider) RenderEnv(projectDir string) error {
+	content := fmt.Sprintf("LLM_PROVIDER=openai_compatible\nOPENAI_COMPATIBLE_BASE_URL=%s\nOPENAI_COMPATIBLE_API_KEY=%s\nGENERATION_MODEL=%s\n", p.BaseURL, p.APIKey, p.Model)
+	return appendEnvFragment(projectDir, content)
+}
@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/manifoldco/promptui"
+)
+
+func init() {
+	Register(&OllamaProvider{})
+}
+
+// OllamaProvider talks to a local (or remote) Ollama server. The model list
+// is fetched live from the server's /api/tags endpoint rather than hardcoded,
+// since it depends on what the user has already pulled.
+type OllamaProvider struct {
+	BaseURL string
+	Model   string
+}
+
+func (p *OllamaProvider) Name() string { return "Ollama" }
+
+func (p *OllamaProvider) Models() []string {
+	models, err := fetchOllamaModels(p.BaseURL)
+	if err != nil {
+		return nil
+	}
+	return models
+}
+
+func (p *OllamaProvider) Prompt() error {
+	urlPrompt := promptui.Prompt{Label: "Ollama base URL", Default: "http://localhost:11434"}
+	baseURL, err := urlPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.BaseURL = baseURL
+
+	models, err := fetchOllamaModels(p.BaseURL)
+	if err != nil || len(models) == 0 {
+		fmt.Println("Could not list local models, please type the model name you want to use")
+		modelPrompt := promptui.Prompt{Label: "Ollama model"}
+		model, err := modelPrompt.Run()
+		if err != nil {
+			return fmt.Errorf("prompt failed: %w", err)
+		}
+		p.Model = model
+		return nil
+	}
+
+	modelPrompt := promptui.Select{Label: "Select a local Ollama model", Items: models}
+	_, model, err := modelPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Model = model
+
+	return nil
+}
+
+func (p *OllamaProvider) Validate() error {
+	if p.BaseURL == "" {
+		return errors.New("Ollama base URL is required")
+	}
+	if p.Model == "" {
+		return errors.New("Ollama model is required")
+	}
+	return nil
+}
+
+// RenderEnv appends Ollama's fragment to .env.ai rather than overwriting it,
+// since utils.PrepareDockerFiles has already written the ports and
+// telemetry settings there.
+func (p *OllamaProvider) RenderEnv(projectDir string) error {
+	content := fmt.Sprintf("LLM_PROVIDER=ollama\nOLLAMA_BASE_URL=%s\nGENERATION_MODEL=%s\n", p.BaseURL, p.Model)
+	return appendEnvFragment(projectDir, content)
+}
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+func fetchOllamaModels(baseURL string) ([]string, error) {
+	if baseURL == "" {
+		return nil, errors.New("Ollama base URL is required")
+	}
+
+	client := http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get(baseURL + "/api/tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Ollama at %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama returned status %d", resp.StatusCode)
+	}
+
+	var tags ollamaTagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to parse Ollama response: %w", err)
+	}
+
+	models := make([]string, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		models = append(models, m.Name)
+	}
+	return models, nil
+}
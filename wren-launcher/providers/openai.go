@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+func init() {
+	Register(&OpenAIProvider{})
+}
+
+// OpenAIProvider is the default, hosted OpenAI backend.
+type OpenAIProvider struct {
+	APIKey string
+	Model  string
+}
+
+func (p *OpenAIProvider) Name() string { return "OpenAI" }
+
+func (p *OpenAIProvider) Models() []string {
+	return []string{"gpt-4o", "gpt-4-turbo", "gpt-3.5-turbo"}
+}
+
+func (p *OpenAIProvider) Prompt() error {
+	fmt.Println("Please provide your OpenAI API key")
+	fmt.Println("Please use the key with full permission, more details at https://help.openai.com/en/articles/8867743-assign-api-key-permissions")
+
+	keyPrompt := promptui.Prompt{
+		Label: "OpenAI API key",
+		Validate: func(input string) error {
+			if !strings.HasPrefix(input, "sk-") {
+				return errors.New("invalid API key")
+			}
+			return nil
+		},
+		Mask: '*',
+	}
+
+	key, err := keyPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.APIKey = key
+
+	fmt.Println("Please provide the generation model you want to use")
+	fmt.Println("You can learn more about OpenAI's generation models at https://platform.openai.com/docs/models/models")
+
+	modelPrompt := promptui.Select{
+		Label: "Select an OpenAI's generation model",
+		Items: p.Models(),
+	}
+
+	_, model, err := modelPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	p.Model = model
+
+	return nil
+}
+
+func (p *OpenAIProvider) Validate() error {
+	if !strings.HasPrefix(p.APIKey, "sk-") {
+		return errors.New("invalid OpenAI API key: expected it to start with \"sk-\"")
+	}
+	if p.Model == "" {
+		return errors.New("generation model is required")
+	}
+	return nil
+}
+
+// RenderEnv is a no-op: utils.PrepareDockerFiles already writes OpenAI's
+// LLM_PROVIDER/OPENAI_API_KEY/GENERATION_MODEL lines directly into .env.ai
+// as part of downloading the compose/env templates, so there is nothing
+// left for the provider to add.
+func (p *OpenAIProvider) RenderEnv(projectDir string) error {
+	return nil
+}
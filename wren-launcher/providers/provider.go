@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+// Provider is implemented by each supported LLM backend. It is responsible
+// for gathering its own configuration (interactively or otherwise),
+// validating it, and rendering its fragment of .env.ai, mirroring the
+// gRPC-backend registration model where each backend owns its own wiring
+// instead of being hardcoded into a single switch statement.
+type Provider interface {
+	// Name is the label shown in the provider selection prompt.
+	Name() string
+
+	// Models lists the models this provider supports, if the list is known
+	// ahead of time (e.g. fetched from a local /api/tags endpoint). Providers
+	// that accept an arbitrary model name can return an empty slice.
+	Models() []string
+
+	// Prompt interactively gathers anything this provider needs (API key,
+	// endpoint, model) that wasn't already supplied.
+	Prompt() error
+
+	// Validate checks that the gathered configuration is well-formed before
+	// any Docker container is started.
+	Validate() error
+
+	// RenderEnv writes this provider's fragment of .env.ai (and any
+	// additional per-model config files) under projectDir.
+	RenderEnv(projectDir string) error
+}
+
+// registry holds the built-in providers in the order they should be
+// presented to the user.
+var registry = []Provider{}
+
+// Register adds a provider to the registry. Built-in providers register
+// themselves from an init() function in their own file.
+func Register(p Provider) {
+	registry = append(registry, p)
+}
+
+// All returns the registered providers in registration order.
+func All() []Provider {
+	return registry
+}
+
+// Names returns the registered provider names, in registration order, for
+// use in a promptui.Select.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for _, p := range registry {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+// appendEnvFragment appends content to projectDir's .env.ai, creating it if
+// it doesn't exist yet. It is used by RenderEnv implementations instead of
+// os.WriteFile so a provider's fragment doesn't truncate whatever
+// utils.PrepareDockerFiles (ports, telemetry, templates) already wrote there.
+func appendEnvFragment(projectDir string, content string) error {
+	envFilePath := path.Join(projectDir, ".env.ai")
+	f, err := os.OpenFile(envFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to append to %s: %w", envFilePath, err)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	return err
+}
+
+// Find returns the registered provider with the given name.
+func Find(name string) (Provider, error) {
+	for _, p := range registry {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown LLM provider %q", name)
+}
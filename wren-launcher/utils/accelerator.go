@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// AcceleratorInfo describes which hardware accelerators were found on the
+// host, so the launcher can pick a matching Docker Compose profile instead
+// of always running the CPU-only images.
+type AcceleratorInfo struct {
+	NVIDIA bool
+	ROCm   bool
+	Metal  bool
+}
+
+// DetectAccelerators probes for NVIDIA (via nvidia-smi), AMD ROCm (via the
+// /dev/kfd device node), and Apple Metal (Apple Silicon macOS).
+func DetectAccelerators() AcceleratorInfo {
+	info := AcceleratorInfo{}
+
+	if _, err := exec.LookPath("nvidia-smi"); err == nil {
+		if err := exec.Command("nvidia-smi").Run(); err == nil {
+			info.NVIDIA = true
+		}
+	}
+
+	if _, err := os.Stat("/dev/kfd"); err == nil {
+		info.ROCm = true
+	}
+
+	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+		info.Metal = true
+	}
+
+	return info
+}
+
+// ComposeProfile is a Docker Compose profile name matching the accelerator
+// images the Wren AI compose file ships.
+type ComposeProfile string
+
+const (
+	ProfileCPU  ComposeProfile = "cpu"
+	ProfileCUDA ComposeProfile = "cuda"
+	ProfileROCm ComposeProfile = "rocm"
+)
+
+// SelectComposeProfile resolves the --gpu flag ("auto", "none", "cuda",
+// "rocm") against what was actually detected, falling back to the CPU
+// profile when nothing usable is found. info.Metal is not consulted: there
+// is no Metal compose profile, so an Apple Silicon host gets the CPU profile
+// under "auto".
+func SelectComposeProfile(pref string, info AcceleratorInfo) (ComposeProfile, error) {
+	switch pref {
+	case "", "auto":
+		if info.NVIDIA {
+			return ProfileCUDA, nil
+		}
+		if info.ROCm {
+			return ProfileROCm, nil
+		}
+		return ProfileCPU, nil
+	case "none":
+		return ProfileCPU, nil
+	case "cuda":
+		if !info.NVIDIA {
+			return "", errors.New("--gpu=cuda requested but no NVIDIA GPU was detected")
+		}
+		return ProfileCUDA, nil
+	case "rocm":
+		if !info.ROCm {
+			return "", errors.New("--gpu=rocm requested but no AMD ROCm device was detected")
+		}
+		return ProfileROCm, nil
+	default:
+		return "", fmt.Errorf("unknown --gpu value %q, expected one of: auto, none, cuda, rocm", pref)
+	}
+}
+
+// ApplyComposeProfile sets COMPOSE_PROFILES in the current process
+// environment so the subsequent `docker compose` invocation picks the right
+// profile and image tag suffix without needing its own --profile flag.
+func ApplyComposeProfile(profile ComposeProfile) error {
+	return os.Setenv("COMPOSE_PROFILES", string(profile))
+}
@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestSelectComposeProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		pref    string
+		info    AcceleratorInfo
+		want    ComposeProfile
+		wantErr bool
+	}{
+		{name: "auto prefers nvidia", pref: "auto", info: AcceleratorInfo{NVIDIA: true, ROCm: true}, want: ProfileCUDA},
+		{name: "auto falls back to rocm", pref: "auto", info: AcceleratorInfo{ROCm: true}, want: ProfileROCm},
+		{name: "auto falls back to cpu", pref: "auto", info: AcceleratorInfo{}, want: ProfileCPU},
+		{name: "empty pref behaves like auto", pref: "", info: AcceleratorInfo{NVIDIA: true}, want: ProfileCUDA},
+		{name: "none forces cpu even with a GPU present", pref: "none", info: AcceleratorInfo{NVIDIA: true}, want: ProfileCPU},
+		{name: "cuda requested and available", pref: "cuda", info: AcceleratorInfo{NVIDIA: true}, want: ProfileCUDA},
+		{name: "cuda requested but missing", pref: "cuda", info: AcceleratorInfo{}, wantErr: true},
+		{name: "rocm requested and available", pref: "rocm", info: AcceleratorInfo{ROCm: true}, want: ProfileROCm},
+		{name: "rocm requested but missing", pref: "rocm", info: AcceleratorInfo{}, wantErr: true},
+		{name: "unknown pref", pref: "tpu", info: AcceleratorInfo{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectComposeProfile(tt.pref, tt.info)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SelectComposeProfile(%q, %+v) = %q, expected an error", tt.pref, tt.info, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SelectComposeProfile(%q, %+v) returned unexpected error: %v", tt.pref, tt.info, err)
+			}
+			if got != tt.want {
+				t.Errorf("SelectComposeProfile(%q, %+v) = %q, want %q", tt.pref, tt.info, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+)
+
+const composeFileName = "docker-compose.yaml"
+
+func composeCommand(projectDir, projectName string, args ...string) *exec.Cmd {
+	composeFile := path.Join(projectDir, composeFileName)
+	fullArgs := append([]string{"compose", "-f", composeFile, "--project-name", projectName}, args...)
+
+	cmd := exec.Command("docker", fullArgs...)
+	cmd.Dir = projectDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd
+}
+
+// StopDockerCompose stops the Wren AI containers while preserving their
+// volumes, so a later `up` picks up where the user left off.
+func StopDockerCompose(projectName string, projectDir string) error {
+	return composeCommand(projectDir, projectName, "down").Run()
+}
+
+// TailDockerComposeLogs streams logs for the given service (or every
+// service, if empty), following new output when follow is true.
+func TailDockerComposeLogs(projectName string, projectDir string, service string, follow bool) error {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	if service != "" {
+		args = append(args, service)
+	}
+	return composeCommand(projectDir, projectName, args...).Run()
+}
+
+// PullDockerComposeImages pulls the latest images for the current compose
+// file, used by `upgrade` before restarting the stack.
+func PullDockerComposeImages(projectName string, projectDir string) error {
+	return composeCommand(projectDir, projectName, "pull").Run()
+}
+
+// BackupProjectDir copies projectDir to a sibling ".bak-<timestamp>"-style
+// directory name supplied by the caller, so an upgrade can be undone by
+// restoring it. Implemented as a plain recursive copy rather than
+// shelling out to `cp -R`, since that isn't available on a stock Windows
+// install.
+func BackupProjectDir(projectDir string, backupDir string) error {
+	return filepath.WalkDir(projectDir, func(srcPath string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(projectDir, srcPath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(backupDir, relPath)
+
+		if d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(dstPath, info.Mode())
+		}
+
+		return copyFile(srcPath, dstPath)
+	})
+}
+
+// copyFile copies a single file, preserving its permissions.
+func copyFile(srcPath string, dstPath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+// RemoveProjectDir destructively deletes projectDir, used by `reset` after
+// the user has confirmed.
+func RemoveProjectDir(projectDir string) error {
+	return os.RemoveAll(projectDir)
+}
+
+// CheckPortInUse reports whether something is already listening on port,
+// which would conflict with bringing the stack up.
+func CheckPortInUse(port int) bool {
+	return !isPortAvailable(port)
+}
+
+func isPortAvailable(port int) bool {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}
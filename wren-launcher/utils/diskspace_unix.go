@@ -0,0 +1,19 @@
+//go:build !windows
+
+package utils
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// CheckDiskSpace returns the number of bytes available to an unprivileged
+// user on the filesystem containing path, used by `doctor` to warn before a
+// `docker compose pull` runs out of room.
+func CheckDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("failed to stat disk space for %s: %w", path, err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
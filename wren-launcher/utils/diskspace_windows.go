@@ -0,0 +1,37 @@
+//go:build windows
+
+package utils
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procGetDiskFreeSpace = kernel32.NewProc("GetDiskFreeSpaceExW")
+)
+
+// CheckDiskSpace returns the number of bytes available to the current user
+// on the filesystem containing path, used by `doctor` to warn before a
+// `docker compose pull` runs out of room.
+func CheckDiskSpace(path string) (uint64, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat disk space for %s: %w", path, err)
+	}
+
+	var freeBytesAvailable uint64
+	ret, _, callErr := procGetDiskFreeSpace.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		0,
+		0,
+	)
+	if ret == 0 {
+		return 0, fmt.Errorf("failed to stat disk space for %s: %w", path, callErr)
+	}
+
+	return freeBytesAvailable, nil
+}
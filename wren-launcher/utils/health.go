@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// pingHTTP issues a short-timeout GET against url and treats any 2xx/3xx
+// response as healthy. It's shared by the per-service health checks below.
+func pingHTTP(url string) error {
+	client := http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// CheckQdrantStarted reports whether the Qdrant container is accepting
+// requests on port.
+func CheckQdrantStarted(port int) error {
+	return pingHTTP(fmt.Sprintf("http://localhost:%d/healthz", port))
+}
+
+// CheckWrenEngineStarted reports whether the Wren Engine container is
+// accepting requests on port.
+func CheckWrenEngineStarted(port int) error {
+	return pingHTTP(fmt.Sprintf("http://localhost:%d/health", port))
+}
+
+// CheckIbisServerStarted reports whether the Ibis server container is
+// accepting requests on port.
+func CheckIbisServerStarted(port int) error {
+	return pingHTTP(fmt.Sprintf("http://localhost:%d/health", port))
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// VerifyLLMCredentials issues a minimal completion request against the
+// chosen provider and surfaces the concrete error (invalid key, model not
+// entitled, region blocked, quota exhausted) before the launcher spends 2+
+// minutes bringing up Docker. Providers it doesn't know how to preflight are
+// treated as valid, since there's nothing cheap to check ahead of time.
+func VerifyLLMCredentials(provider string, key string, model string) error {
+	switch provider {
+	case "OpenAI":
+		return verifyOpenAICredentials(key, model)
+	case "Anthropic":
+		return verifyAnthropicCredentials(key, model)
+	default:
+		return nil
+	}
+}
+
+// VerifyEmbeddingModel preflights an embedding model the same way
+// VerifyLLMCredentials preflights the generation model. Providers without a
+// cheap way to check embedding model availability ahead of time (e.g.
+// Anthropic, which has no embeddings API) are treated as valid.
+func VerifyEmbeddingModel(provider string, key string, model string) error {
+	switch provider {
+	case "OpenAI":
+		return verifyOpenAIEmbeddingModel(key, model)
+	default:
+		return nil
+	}
+}
+
+func verifyOpenAIEmbeddingModel(key string, model string) error {
+	body, _ := json.Marshal(map[string]interface{}{
+		"model": model,
+		"input": "ping",
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPreflightRequest(req)
+}
+
+func verifyOpenAICredentials(key string, model string) error {
+	body, _ := json.Marshal(map[string]interface{}{
+		"model":      model,
+		"messages":   []map[string]string{{"role": "user", "content": "ping"}},
+		"max_tokens": 1,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPreflightRequest(req)
+}
+
+func verifyAnthropicCredentials(key string, model string) error {
+	body, _ := json.Marshal(map[string]interface{}{
+		"model":      model,
+		"messages":   []map[string]string{{"role": "user", "content": "ping"}},
+		"max_tokens": 1,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", key)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPreflightRequest(req)
+}
+
+// doPreflightRequest sends req and translates common failure status codes
+// into an actionable error message.
+func doPreflightRequest(req *http.Request) error {
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 400 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s rejected the API key (status %d): %s", req.URL.Host, resp.StatusCode, respBody)
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		return fmt.Errorf("%s does not recognize the model (status %d): %s", req.URL.Host, resp.StatusCode, respBody)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%s reported quota exhausted (status %d): %s", req.URL.Host, resp.StatusCode, respBody)
+	default:
+		return fmt.Errorf("%s returned status %d: %s", req.URL.Host, resp.StatusCode, respBody)
+	}
+}